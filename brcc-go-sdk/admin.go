@@ -0,0 +1,185 @@
+package rcc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// adminStatus holds the state reported by the admin API's /rcc/status
+// endpoint.
+type adminStatus struct {
+	mu sync.RWMutex
+
+	lastSyncTime    time.Time
+	lastVersionId   int
+	lastErr         string
+	loadedFromCache bool
+	pollerRunning   bool
+	restartCount    int
+	lastPanic       string
+}
+
+func (s *adminStatus) recordSync(versionId int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSyncTime = time.Now()
+	s.lastVersionId = versionId
+	if err != nil {
+		s.lastErr = err.Error()
+	} else {
+		s.lastErr = ""
+	}
+}
+
+func (s *adminStatus) setLoadedFromCache(v bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedFromCache = v
+}
+
+func (s *adminStatus) setPollerRunning(v bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pollerRunning = v
+}
+
+func (s *adminStatus) recordPollerRestart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restartCount++
+}
+
+func (s *adminStatus) recordPanic(stack string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastPanic = stack
+}
+
+func (s *adminStatus) snapshot() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return map[string]interface{}{
+		"lastSyncTime":    s.lastSyncTime,
+		"lastVersionId":   s.lastVersionId,
+		"lastErr":         s.lastErr,
+		"loadedFromCache": s.loadedFromCache,
+		"pollerRunning":   s.pollerRunning,
+		"restartCount":    s.restartCount,
+		"lastPanic":       s.lastPanic,
+	}
+}
+
+// startAdminServer starts the opt-in HTTP admin server used for runtime
+// introspection and forced refresh. It is a no-op when Conf.AdminListen is
+// empty.
+func (c *Client) startAdminServer() error {
+	if c.conf.AdminListen == "" {
+		return nil
+	}
+
+	redact := make([]*regexp.Regexp, 0, len(c.conf.AdminRedactKeys))
+	for _, pattern := range c.conf.AdminRedactKeys {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("[rcc-go-client]invalid conf.AdminRedactKeys pattern %q: %w", pattern, err)
+		}
+		redact = append(redact, re)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rcc/keys", c.handleAdminKeys)
+	mux.HandleFunc("/rcc/values", func(w http.ResponseWriter, r *http.Request) {
+		c.handleAdminValues(w, r, redact)
+	})
+	mux.HandleFunc("/rcc/value", func(w http.ResponseWriter, r *http.Request) {
+		c.handleAdminValue(w, r, redact)
+	})
+	mux.HandleFunc("/rcc/refresh", c.handleAdminRefresh)
+	mux.HandleFunc("/rcc/status", c.handleAdminStatus)
+
+	ln, err := net.Listen("tcp", c.conf.AdminListen)
+	if err != nil {
+		return fmt.Errorf("[rcc-go-client]admin listen on %s: %w", c.conf.AdminListen, err)
+	}
+
+	c.adminServer = &http.Server{Handler: mux}
+	go func() {
+		if err := c.adminServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			c.conf.Logger.Error("[rcc-go-client]admin server stopped", "err", err)
+		}
+	}()
+	return nil
+}
+
+// stopAdminServer shuts down the admin server started by startAdminServer,
+// if any.
+func (c *Client) stopAdminServer() error {
+	if c.adminServer == nil {
+		return nil
+	}
+	return c.adminServer.Close()
+}
+
+func (c *Client) handleAdminKeys(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, c.GetAllKeys())
+}
+
+func (c *Client) handleAdminValues(w http.ResponseWriter, r *http.Request, redact []*regexp.Regexp) {
+	kv := c.cache.dump()
+	for k := range kv {
+		if isAdminRedacted(k, redact) {
+			kv[k] = "***redacted***"
+		}
+	}
+	writeAdminJSON(w, kv)
+}
+
+func (c *Client) handleAdminValue(w http.ResponseWriter, r *http.Request, redact []*regexp.Regexp) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "missing key query parameter", http.StatusBadRequest)
+		return
+	}
+	value := c.GetValue(key, "")
+	if isAdminRedacted(key, redact) {
+		value = "***redacted***"
+	}
+	writeAdminJSON(w, map[string]string{"key": key, "value": value})
+}
+
+func (c *Client) handleAdminRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := c.handleUpdate(0); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeAdminJSON(w, map[string]string{"result": "ok"})
+}
+
+func (c *Client) handleAdminStatus(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, c.adminStatus.snapshot())
+}
+
+func isAdminRedacted(key string, redact []*regexp.Regexp) bool {
+	for _, re := range redact {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,100 @@
+package rcc
+
+import (
+	"sync"
+)
+
+// Bus fans ChangeEvents out to every Watch/WatchUpdate/WatchKey subscriber.
+// It replaces the old pattern of handing callers the client's single
+// updateChan, where a second Watch/WatchUpdate call would silently share
+// (and compete for) the same channel.
+type Bus struct {
+	mu      sync.RWMutex
+	subs    map[chan *ChangeEvent]struct{}
+	keySubs map[string][]func(*Change)
+
+	logger Logger
+}
+
+func newBus(logger Logger) *Bus {
+	return &Bus{
+		subs:    make(map[chan *ChangeEvent]struct{}),
+		keySubs: make(map[string][]func(*Change)),
+		logger:  logger,
+	}
+}
+
+// Subscribe registers ch to receive every future ChangeEvent published on
+// the bus. The caller owns ch and must eventually Unsubscribe it.
+func (b *Bus) Subscribe(ch chan *ChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[ch] = struct{}{}
+}
+
+// Unsubscribe removes ch from the bus. It does not close ch.
+func (b *Bus) Unsubscribe(ch chan *ChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs, ch)
+}
+
+// WatchKey registers cb to be invoked with the Change whenever key is
+// added, modified or deleted, without the caller having to filter the
+// full ChangeEvent map themselves.
+func (b *Bus) WatchKey(key string, cb func(*Change)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.keySubs[key] = append(b.keySubs[key], cb)
+}
+
+// Publish fans change out to every subscriber and keyed callback. A
+// subscriber whose channel is full is skipped rather than blocked, so one
+// slow consumer can't stall delivery to the rest or stall the poller that
+// calls Publish. Keyed callbacks are snapshotted and invoked after the lock
+// is released, so a callback that re-subscribes via WatchKey (or calls
+// Unsubscribe) can't deadlock on b.mu, and a panicking callback can't take
+// down the poller goroutine that calls Publish.
+func (b *Bus) Publish(change *ChangeEvent) {
+	b.mu.RLock()
+	for ch := range b.subs {
+		select {
+		case ch <- change:
+		default:
+			b.logger.Warn("[rcc-go-client]subscriber channel full, dropping change event",
+				"chanLen", len(ch), "chanCap", cap(ch))
+		}
+	}
+
+	type keyedCallback struct {
+		change *Change
+		cb     func(*Change)
+	}
+	var keyed []keyedCallback
+	for key, cbs := range b.keySubs {
+		c, ok := change.Changes[key]
+		if !ok {
+			continue
+		}
+		for _, cb := range cbs {
+			keyed = append(keyed, keyedCallback{change: c, cb: cb})
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, k := range keyed {
+		b.invokeKeyCallback(k.cb, k.change)
+	}
+}
+
+// invokeKeyCallback runs cb, recovering from a panic the same way Watch's
+// callback dispatch does, so one misbehaving WatchKey subscriber can't take
+// down the poller goroutine that calls Publish.
+func (b *Bus) invokeKeyCallback(cb func(*Change), change *Change) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("[rcc-go-client]watch key callback function panic")
+		}
+	}()
+	cb(change)
+}
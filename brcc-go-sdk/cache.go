@@ -0,0 +1,93 @@
+package rcc
+
+import "sync"
+
+// CacheStore is the pluggable backing store for a Client's Cache. Swapping
+// the CacheStore changes how values are held in memory and how the dump
+// file is persisted, without processResult (or any other caller) needing
+// to know which backend is active.
+type CacheStore interface {
+	Get(key string) (string, bool)
+	Set(key, value string)
+	Delete(key string)
+	Range(fn func(key, value string) bool)
+	Dump() map[string]string
+	Load(fileName string) error
+	Store(fileName string) error
+}
+
+// Cache is a thin facade over the Conf-selected CacheStore. It additionally
+// tracks a hash of each value, so diffing an incoming value against the
+// cached one is a uint64 comparison rather than a string comparison.
+type Cache struct {
+	backend CacheStore
+	hashes  sync.Map // key -> uint64
+}
+
+func newCache(conf *Conf) *Cache {
+	return &Cache{backend: newCacheStore(conf)}
+}
+
+func newCacheStore(conf *Conf) CacheStore {
+	switch conf.CacheBackend {
+	case CacheBackendLRU:
+		return newLRUCacheStore(conf.CacheMaxBytes, conf.CacheCompression, conf.CacheKeepVersions)
+	case CacheBackendEncrypted:
+		return newEncryptedFileCacheStore(conf.CacheSecret, conf.CacheCompression, conf.CacheKeepVersions)
+	default:
+		return newSyncMapCacheStore(conf.CacheCompression, conf.CacheKeepVersions)
+	}
+}
+
+func (c *Cache) get(key string) (string, bool) { return c.backend.Get(key) }
+
+func (c *Cache) set(key, value string) {
+	c.backend.Set(key, value)
+	c.hashes.Store(key, hashValue(value))
+}
+
+func (c *Cache) delete(key string) {
+	c.backend.Delete(key)
+	c.hashes.Delete(key)
+}
+
+func (c *Cache) dump() map[string]string { return c.backend.Dump() }
+
+// load restores the backend from fileName and repopulates c.hashes from the
+// restored values, so a key loaded from disk is indistinguishable from one
+// that arrived through set - otherwise hashOf would report every restored
+// key as unseen and the next processChunk would emit a spurious
+// ChangeModify for it even though its value hasn't changed.
+func (c *Cache) load(fileName string) error {
+	if err := c.backend.Load(fileName); err != nil {
+		return err
+	}
+	c.backend.Range(func(key, value string) bool {
+		c.hashes.Store(key, hashValue(value))
+		return true
+	})
+	return nil
+}
+
+func (c *Cache) store(fileName string) error { return c.backend.Store(fileName) }
+
+// hashOf returns the cached hash of key's current value, so callers can
+// detect an unchanged value in O(1) without comparing the strings.
+func (c *Cache) hashOf(key string) (uint64, bool) {
+	v, ok := c.hashes.Load(key)
+	if !ok {
+		return 0, false
+	}
+	h, ok := v.(uint64)
+	return h, ok
+}
+
+// keys returns every key currently held by the backend.
+func (c *Cache) keys() []string {
+	var keys []string
+	c.backend.Range(func(key, _ string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
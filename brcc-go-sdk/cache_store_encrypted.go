@@ -0,0 +1,125 @@
+package rcc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// encryptedFileCacheStore is a sync.Map-backed CacheStore whose dump file
+// is AES-GCM encrypted with a key derived from Conf.CacheSecret, so secrets
+// pulled from RCC aren't sitting in plaintext under CacheDir.
+type encryptedFileCacheStore struct {
+	kv  sync.Map
+	key [32]byte
+
+	compress     bool
+	keepVersions int
+}
+
+func newEncryptedFileCacheStore(secret string, compress bool, keepVersions int) *encryptedFileCacheStore {
+	return &encryptedFileCacheStore{
+		key:          sha256.Sum256([]byte(secret)),
+		compress:     compress,
+		keepVersions: keepVersions,
+	}
+}
+
+func (e *encryptedFileCacheStore) Get(key string) (string, bool) {
+	v, ok := e.kv.Load(key)
+	if !ok {
+		return "", false
+	}
+	str, ok := v.(string)
+	return str, ok
+}
+
+func (e *encryptedFileCacheStore) Set(key, value string) { e.kv.Store(key, value) }
+
+func (e *encryptedFileCacheStore) Delete(key string) { e.kv.Delete(key) }
+
+func (e *encryptedFileCacheStore) Range(fn func(key, value string) bool) {
+	e.kv.Range(func(k, v interface{}) bool {
+		key, ok := k.(string)
+		if !ok {
+			return true
+		}
+		value, _ := v.(string)
+		return fn(key, value)
+	})
+}
+
+func (e *encryptedFileCacheStore) Dump() map[string]string {
+	kv := make(map[string]string)
+	e.Range(func(key, value string) bool {
+		kv[key] = value
+		return true
+	})
+	return kv
+}
+
+func (e *encryptedFileCacheStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(e.key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *encryptedFileCacheStore) Store(fileName string) error {
+	data, err := json.Marshal(newDumpFile(e.Dump()))
+	if err != nil {
+		return err
+	}
+
+	gcm, err := e.gcm()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, data, nil)
+	return writeDumpPayload(fileName, ciphertext, e.compress, e.keepVersions)
+}
+
+func (e *encryptedFileCacheStore) Load(fileName string) error {
+	ciphertext, err := readDumpPayload(fileName, e.compress, e.keepVersions)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := e.gcm()
+	if err != nil {
+		return err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return fmt.Errorf("cache file %s is too short to be a valid encrypted dump", fileName)
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	data, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return fmt.Errorf("decrypt cache file %s: %w", fileName, err)
+	}
+
+	var df dumpFile
+	if err := json.Unmarshal(data, &df); err != nil {
+		return err
+	}
+	kv, err := df.toKV()
+	if err != nil {
+		return err
+	}
+	for k, v := range kv {
+		e.Set(k, v)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rcc
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestEncryptedFileCacheStore_StoreLoadRoundTrip(t *testing.T) {
+	f, err := ioutil.TempFile("", "rcc-encrypted-dump-*")
+	if err != nil {
+		t.Fatalf("TempFile: %v", err)
+	}
+	fileName := f.Name()
+	f.Close()
+	defer os.Remove(fileName)
+
+	store := newEncryptedFileCacheStore("s3cr3t", false, 0)
+	store.Set("foo", "bar")
+	store.Set("baz", "qux")
+
+	if err := store.Store(fileName); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	loaded := newEncryptedFileCacheStore("s3cr3t", false, 0)
+	if err := loaded.Load(fileName); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if v, ok := loaded.Get("foo"); !ok || v != "bar" {
+		t.Fatalf("Get(foo) = %q, %v, want bar, true", v, ok)
+	}
+	if v, ok := loaded.Get("baz"); !ok || v != "qux" {
+		t.Fatalf("Get(baz) = %q, %v, want qux, true", v, ok)
+	}
+}
+
+func TestEncryptedFileCacheStore_LoadWithWrongSecretFails(t *testing.T) {
+	f, err := ioutil.TempFile("", "rcc-encrypted-dump-*")
+	if err != nil {
+		t.Fatalf("TempFile: %v", err)
+	}
+	fileName := f.Name()
+	f.Close()
+	defer os.Remove(fileName)
+
+	store := newEncryptedFileCacheStore("s3cr3t", false, 0)
+	store.Set("foo", "bar")
+	if err := store.Store(fileName); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	wrongKey := newEncryptedFileCacheStore("a-different-secret", false, 0)
+	if err := wrongKey.Load(fileName); err == nil {
+		t.Fatalf("Load with wrong secret: expected error, got nil")
+	}
+}
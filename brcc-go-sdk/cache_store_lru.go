@@ -0,0 +1,102 @@
+package rcc
+
+import (
+	"encoding/json"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// defaultLRUEntries is used when Conf.CacheMaxBytes is unset.
+const defaultLRUEntries = 4096
+
+// assumedAvgEntryBytes converts Conf.CacheMaxBytes into an entry count.
+// golang-lru sizes by entry count rather than bytes, so this is a sizing
+// hint rather than an exact memory bound.
+const assumedAvgEntryBytes = 512
+
+// lruCacheStore is a memory-bounded CacheStore for services that pull huge
+// namespaces and don't want the sync.Map store's unbounded growth.
+type lruCacheStore struct {
+	cache *lru.Cache
+
+	compress     bool
+	keepVersions int
+}
+
+func newLRUCacheStore(maxBytes int64, compress bool, keepVersions int) *lruCacheStore {
+	size := defaultLRUEntries
+	if maxBytes > 0 {
+		if n := int(maxBytes / assumedAvgEntryBytes); n > 0 {
+			size = n
+		}
+	}
+	// lru.New only errors on a non-positive size, which size can't be here.
+	cache, _ := lru.New(size)
+	return &lruCacheStore{cache: cache, compress: compress, keepVersions: keepVersions}
+}
+
+func (l *lruCacheStore) Get(key string) (string, bool) {
+	v, ok := l.cache.Get(key)
+	if !ok {
+		return "", false
+	}
+	str, ok := v.(string)
+	return str, ok
+}
+
+func (l *lruCacheStore) Set(key, value string) { l.cache.Add(key, value) }
+
+func (l *lruCacheStore) Delete(key string) { l.cache.Remove(key) }
+
+func (l *lruCacheStore) Range(fn func(key, value string) bool) {
+	for _, k := range l.cache.Keys() {
+		key, ok := k.(string)
+		if !ok {
+			continue
+		}
+		v, ok := l.cache.Peek(k)
+		if !ok {
+			continue
+		}
+		value, _ := v.(string)
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+func (l *lruCacheStore) Dump() map[string]string {
+	kv := make(map[string]string)
+	l.Range(func(key, value string) bool {
+		kv[key] = value
+		return true
+	})
+	return kv
+}
+
+func (l *lruCacheStore) Store(fileName string) error {
+	data, err := json.Marshal(newDumpFile(l.Dump()))
+	if err != nil {
+		return err
+	}
+	return writeDumpPayload(fileName, data, l.compress, l.keepVersions)
+}
+
+func (l *lruCacheStore) Load(fileName string) error {
+	data, err := readDumpPayload(fileName, l.compress, l.keepVersions)
+	if err != nil {
+		return err
+	}
+	var df dumpFile
+	if err := json.Unmarshal(data, &df); err != nil {
+		return err
+	}
+	kv, err := df.toKV()
+	if err != nil {
+		return err
+	}
+	for k, v := range kv {
+		l.Set(k, v)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package rcc
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// syncMapCacheStore is the original unbounded, in-memory CacheStore backed
+// by sync.Map.
+type syncMapCacheStore struct {
+	kv sync.Map
+
+	compress     bool
+	keepVersions int
+}
+
+func newSyncMapCacheStore(compress bool, keepVersions int) *syncMapCacheStore {
+	return &syncMapCacheStore{compress: compress, keepVersions: keepVersions}
+}
+
+func (s *syncMapCacheStore) Get(key string) (string, bool) {
+	v, ok := s.kv.Load(key)
+	if !ok {
+		return "", false
+	}
+	str, ok := v.(string)
+	return str, ok
+}
+
+func (s *syncMapCacheStore) Set(key, value string) { s.kv.Store(key, value) }
+
+func (s *syncMapCacheStore) Delete(key string) { s.kv.Delete(key) }
+
+func (s *syncMapCacheStore) Range(fn func(key, value string) bool) {
+	s.kv.Range(func(k, v interface{}) bool {
+		key, ok := k.(string)
+		if !ok {
+			return true
+		}
+		value, _ := v.(string)
+		return fn(key, value)
+	})
+}
+
+func (s *syncMapCacheStore) Dump() map[string]string {
+	kv := make(map[string]string)
+	s.Range(func(key, value string) bool {
+		kv[key] = value
+		return true
+	})
+	return kv
+}
+
+func (s *syncMapCacheStore) Store(fileName string) error {
+	data, err := json.Marshal(newDumpFile(s.Dump()))
+	if err != nil {
+		return err
+	}
+	return writeDumpPayload(fileName, data, s.compress, s.keepVersions)
+}
+
+func (s *syncMapCacheStore) Load(fileName string) error {
+	data, err := readDumpPayload(fileName, s.compress, s.keepVersions)
+	if err != nil {
+		return err
+	}
+	var df dumpFile
+	if err := json.Unmarshal(data, &df); err != nil {
+		return err
+	}
+	kv, err := df.toKV()
+	if err != nil {
+		return err
+	}
+	for k, v := range kv {
+		s.Set(k, v)
+	}
+	return nil
+}
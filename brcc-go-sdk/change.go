@@ -0,0 +1,50 @@
+package rcc
+
+// EventType distinguishes a normal diff ChangeEvent from a signal event
+// like EventStale.
+type EventType string
+
+const (
+	// EventUpdate carries a normal set of add/modify/delete Changes.
+	EventUpdate EventType = "update"
+	// EventStale is delivered (with an empty Changes map) once consecutive
+	// requester.Get failures reach staleFailureThreshold, so callers can
+	// react to an RCC outage (e.g. flip a readiness probe) instead of
+	// blindly trusting the last-known cache.
+	EventStale EventType = "stale"
+)
+
+// ChangeType classifies a single key's Change.
+type ChangeType string
+
+const (
+	ChangeAdd    ChangeType = "add"
+	ChangeModify ChangeType = "modify"
+	ChangeDelete ChangeType = "delete"
+)
+
+// ChangeEvent is delivered to WatchUpdate/Watch subscribers.
+type ChangeEvent struct {
+	Type    EventType
+	Changes map[string]*Change
+}
+
+// Change describes how a single key changed.
+type Change struct {
+	Key      string
+	Type     ChangeType
+	OldValue string
+	NewValue string
+}
+
+func makeAddChange(key, value string) *Change {
+	return &Change{Key: key, Type: ChangeAdd, NewValue: value}
+}
+
+func makeDeleteChange(key, oldValue string) *Change {
+	return &Change{Key: key, Type: ChangeDelete, OldValue: oldValue}
+}
+
+func makeModifyChange(key, oldValue, newValue string) *Change {
+	return &Change{Key: key, Type: ChangeModify, OldValue: oldValue, NewValue: newValue}
+}
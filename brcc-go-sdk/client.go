@@ -6,16 +6,17 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"sync/atomic"
 
 	"github.com/baidu/brcc/brcc-go-sdk/logutil"
-	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 // Client for rcc
 type Client struct {
 	conf *Conf
 
-	updateChan chan *ChangeEvent
+	bus *Bus
 
 	cache *Cache
 
@@ -27,8 +28,23 @@ type Client struct {
 	cancel context.CancelFunc
 
 	isRunning chan bool
+
+	adminServer *http.Server
+	adminStatus *adminStatus
+
+	consecutiveFailures int32
 }
 
+// staleFailureThreshold is how many consecutive requester.Get failures
+// trigger an EventStale ChangeEvent.
+const staleFailureThreshold = 3
+
+// defaultFetchConcurrency is used when Conf.FetchConcurrency is unset.
+const defaultFetchConcurrency = 4
+
+// defaultFetchChunkSize is the page size used to fetch a namespace's items.
+const defaultFetchChunkSize = 500
+
 type Item struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
@@ -46,10 +62,12 @@ func NewClient(ctx context.Context, conf *Conf) (*Client, error) {
 	}
 
 	client := &Client{
-		conf:      conf,
-		cache:     newCache(),
-		requester: newHTTPRequester(ctx, &http.Client{Timeout: conf.RequestTimeout}),
-		isRunning: make(chan bool, 1),
+		conf:        conf,
+		bus:         newBus(conf.Logger),
+		cache:       newCache(conf),
+		requester:   newHTTPRequester(ctx, &http.Client{Timeout: conf.RequestTimeout}),
+		isRunning:   make(chan bool, 1),
+		adminStatus: &adminStatus{},
 	}
 	client.ctx, client.cancel = ctx, cancel
 
@@ -73,23 +91,27 @@ func (c *Client) Start() error {
 		return fmt.Errorf("[rcc-go-client]client has running")
 	}
 
-	c.updateChan = nil
 	c.ctx, c.cancel = context.WithCancel(context.Background())
 
 	// preload all config to local first
 	if err := c.preload(); err != nil {
 		return err
 	}
-	logutil.DefaultLogger().Info("[rcc-go-client]preload success",
-		zap.String("projectName", c.conf.ProjectName),
-		zap.String("envName", c.conf.EnvName))
+	c.conf.Logger.Info("[rcc-go-client]preload success",
+		"projectName", c.conf.ProjectName,
+		"envName", c.conf.EnvName)
 
 	// start fetch update
 	if c.conf.EnableCallback {
-		logutil.DefaultLogger().Info("[rcc-go-client]enable update callback",
-			zap.String("projectName", c.conf.ProjectName),
-			zap.String("envName", c.conf.EnvName))
-		go c.poller.start()
+		c.conf.Logger.Info("[rcc-go-client]enable update callback",
+			"projectName", c.conf.ProjectName,
+			"envName", c.conf.EnvName)
+		go c.runPollerSupervised()
+		c.adminStatus.setPollerRunning(true)
+	}
+
+	if err := c.startAdminServer(); err != nil {
+		return err
 	}
 
 	return nil
@@ -98,45 +120,56 @@ func (c *Client) Start() error {
 // Stop sync config
 func (c *Client) Stop() error {
 	c.poller.stop()
+	c.adminStatus.setPollerRunning(false)
+	if err := c.stopAdminServer(); err != nil {
+		return err
+	}
 	c.cancel()
 	return nil
 }
 
-// WatchUpdate get all updates
+// WatchUpdate returns a channel that receives every future ChangeEvent.
+// Each call registers its own subscriber on the bus, so a second call no
+// longer shares (and competes for) the same channel as the first.
 func (c *Client) WatchUpdate() <-chan *ChangeEvent {
-	if c.updateChan == nil {
-		c.updateChan = make(chan *ChangeEvent, 32)
-	}
-	return c.updateChan
+	ch := make(chan *ChangeEvent, 32)
+	c.bus.Subscribe(ch)
+	return ch
 }
 
-// Watch
+// Watch subscribes callback to every future ChangeEvent.
 func (c *Client) Watch(callback func(ce *ChangeEvent)) {
-	ch := c.WatchUpdate()
+	ch := make(chan *ChangeEvent, 32)
+	c.bus.Subscribe(ch)
 	go func(c *Client) {
+		defer c.bus.Unsubscribe(ch)
 		for {
 			select {
-			case ce, ok := <-ch:
-				if ok {
-					func(ce *ChangeEvent) {
-						// 捕获callback函数抛出的panic
-						defer func() {
-							if r := recover(); r != nil {
-								logutil.DefaultLogger().Error("[rcc-go-client]watch callback function panic",
-									zap.String("projectName", c.conf.ProjectName),
-									zap.String("envName", c.conf.EnvName))
-							}
-						}()
-						callback(ce)
-					}(ce)
-				} else {
-					return
-				}
+			case <-c.ctx.Done():
+				return
+			case ce := <-ch:
+				func(ce *ChangeEvent) {
+					// 捕获callback函数抛出的panic
+					defer func() {
+						if r := recover(); r != nil {
+							c.conf.Logger.Error("[rcc-go-client]watch callback function panic",
+								"projectName", c.conf.ProjectName,
+								"envName", c.conf.EnvName)
+						}
+					}()
+					callback(ce)
+				}(ce)
 			}
 		}
 	}(c)
 }
 
+// WatchKey subscribes cb to changes of a single key, so callers that only
+// care about one value don't need to filter the full ChangeEvent map.
+func (c *Client) WatchKey(key string, cb func(*Change)) {
+	c.bus.WatchKey(key, cb)
+}
+
 // GetValue
 func (c *Client) GetValue(key, defaultValue string) string {
 	if ret, ok := c.cache.get(key); ok && ret != "" {
@@ -147,91 +180,161 @@ func (c *Client) GetValue(key, defaultValue string) string {
 
 // GetAllKeys return all config keys in given namespace
 func (c *Client) GetAllKeys() []string {
-	var keys []string
-	c.cache.kv.Range(func(key, value interface{}) bool {
-		str, ok := key.(string)
-		if ok {
-			keys = append(keys, str)
-		}
-		return true
-	})
-	return keys
+	return c.cache.keys()
 }
 
 func (c *Client) handleUpdate(versionId int) error {
-	change, err := c.sync(versionId)
-	if err != nil || change == nil {
+	_, err := c.sync(versionId)
+	c.adminStatus.recordSync(versionId, err)
+
+	if err != nil {
+		if n := atomic.AddInt32(&c.consecutiveFailures, 1); n == staleFailureThreshold {
+			c.deliveryChangeEvent(&ChangeEvent{Type: EventStale, Changes: map[string]*Change{}})
+		}
 		return err
 	}
+	atomic.StoreInt32(&c.consecutiveFailures, 0)
 
-	c.deliveryChangeEvent(change)
 	return nil
 }
 
-// sync namespace config
+// sync fetches namespace config, chunk by chunk, and diffs it against the
+// cache. All chunks are fetched first; each chunk's ChangeEvent (and, at
+// the end, one for any deleted keys) is then delivered as soon as that
+// chunk has been diffed, rather than as one event for the whole namespace,
+// and the aggregate of all of them is returned.
 func (c *Client) sync(versionId int) (*ChangeEvent, error) {
-
-	url := apiItems(c.conf, versionId)
-	result := make([]Item, 0)
-	err := c.requester.Get(url, &result)
+	chunks, err := c.fetchAllItems(versionId)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.processResult(result), nil
-}
+	agg := &ChangeEvent{Type: EventUpdate, Changes: map[string]*Change{}}
+	seen := make(map[string]struct{})
+
+	for _, chunk := range chunks {
+		resultMap := make(map[string]string, len(chunk))
+		for _, item := range chunk {
+			resultMap[item.Key] = item.Value
+			seen[item.Key] = struct{}{}
+		}
 
-func (c *Client) processResult(result []Item) *ChangeEvent {
-	ret := ChangeEvent{
-		Changes: map[string]*Change{},
+		ce := c.processChunk(resultMap)
+		for k, chg := range ce.Changes {
+			agg.Changes[k] = chg
+		}
+		if len(ce.Changes) > 0 {
+			c.deliveryChangeEvent(ce)
+		}
 	}
 
-	resultMap := make(map[string]string)
-	for _, item := range result {
-		resultMap[item.Key] = item.Value
+	deleted := &ChangeEvent{Type: EventUpdate, Changes: map[string]*Change{}}
+	for _, k := range c.cache.keys() {
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		old, _ := c.cache.get(k)
+		c.cache.delete(k)
+		deleted.Changes[k] = makeDeleteChange(k, old)
+		agg.Changes[k] = deleted.Changes[k]
+	}
+	if len(deleted.Changes) > 0 {
+		c.deliveryChangeEvent(deleted)
 	}
 
-	kv := c.cache.dump()
-	for k, v := range kv {
-		if _, ok := resultMap[k]; !ok {
-			c.cache.delete(k)
-			ret.Changes[k] = makeDeleteChange(k, v)
+	// store caches to file
+	if c.conf.EnableCache {
+		if err := c.storeFile(c.getDumpFileName()); err != nil {
+			c.conf.Logger.Warn("[rcc-go-client]store cache file error", "file", c.getDumpFileName(), "err", err)
 		}
 	}
 
+	return agg, nil
+}
+
+// processChunk diffs one fetched chunk against the cache, using each key's
+// stored hash to skip unchanged entries in O(1) rather than comparing the
+// (potentially large) values themselves. It only handles adds/modifies;
+// deletions need the full key set and are handled once, after all chunks,
+// by sync.
+func (c *Client) processChunk(resultMap map[string]string) *ChangeEvent {
+	ce := &ChangeEvent{Type: EventUpdate, Changes: map[string]*Change{}}
+
 	for k, v := range resultMap {
-		c.cache.set(k, v)
-		old, ok := kv[k]
-		if !ok {
-			ret.Changes[k] = makeAddChange(k, v)
+		newHash := hashValue(v)
+		oldHash, existed := c.cache.hashOf(k)
+
+		// Cache.hashes is never trimmed when a bounded backend (e.g. the
+		// LRU store) evicts a key, so existed alone can't be trusted - it
+		// must agree with the backend actually still holding the key.
+		old, hadOld := c.cache.get(k)
+		if existed && hadOld && oldHash == newHash {
 			continue
 		}
-		if old != v {
-			ret.Changes[k] = makeModifyChange(k, old, v)
-		}
-	}
 
-	// store caches to file
-	if c.conf.EnableCache {
-		err := c.storeFile(c.getDumpFileName())
-		if err != nil {
-			logutil.DefaultLogger().Warn(fmt.Sprintf("store cache file(%s) error: %s", c.getDumpFileName(), err))
+		c.cache.set(k, v)
+
+		if !hadOld {
+			ce.Changes[k] = makeAddChange(k, v)
+			continue
 		}
+		ce.Changes[k] = makeModifyChange(k, old, v)
 	}
 
-	return &ret
+	return ce
 }
 
-// deliveryChangeEvent push change to subscriber
-func (c *Client) deliveryChangeEvent(change *ChangeEvent) {
-	if c.updateChan == nil {
-		return
+// fetchAllItems fetches a namespace's items in Conf.FetchConcurrency
+// concurrent chunks, returning each chunk separately so sync can diff and
+// deliver them incrementally instead of allocating one big slice.
+func (c *Client) fetchAllItems(versionId int) ([][]Item, error) {
+	var first itemsPage
+	if err := c.requester.Get(apiItemsPage(c.conf, versionId, 0, defaultFetchChunkSize), &first); err != nil {
+		return nil, err
 	}
-	select {
-	case <-c.ctx.Done():
-		close(c.updateChan)
-	case c.updateChan <- change:
+
+	chunks := [][]Item{first.Items}
+	if first.Total <= len(first.Items) {
+		return chunks, nil
+	}
+
+	var offsets []int
+	for offset := defaultFetchChunkSize; offset < first.Total; offset += defaultFetchChunkSize {
+		offsets = append(offsets, offset)
+	}
+
+	concurrency := c.conf.FetchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultFetchConcurrency
+	}
+
+	rest := make([][]Item, len(offsets))
+	g, _ := errgroup.WithContext(c.ctx)
+	sem := make(chan struct{}, concurrency)
+	for i, offset := range offsets {
+		i, offset := i, offset
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var page itemsPage
+			if err := c.requester.Get(apiItemsPage(c.conf, versionId, offset, defaultFetchChunkSize), &page); err != nil {
+				return err
+			}
+			rest[i] = page.Items
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
+
+	return append(chunks, rest...), nil
+}
+
+// deliveryChangeEvent push change to every subscriber via the bus
+func (c *Client) deliveryChangeEvent(change *ChangeEvent) {
+	c.bus.Publish(change)
 }
 
 func (c *Client) preload() error {
@@ -241,15 +344,16 @@ func (c *Client) preload() error {
 		}
 		err1 := c.loadFile(c.getDumpFileName())
 		if err1 != nil {
-			logutil.DefaultLogger().Warn(fmt.Sprintf("preload from cache file(%s) error: %s", c.getDumpFileName(), err1))
+			c.conf.Logger.Warn("[rcc-go-client]preload from cache file error", "file", c.getDumpFileName(), "err", err1)
 			return err
 		}
+		c.adminStatus.setLoadedFromCache(true)
 	} else {
 		// store caches to file
 		if c.conf.EnableCache {
 			err := c.storeFile(c.getDumpFileName())
 			if err != nil {
-				logutil.DefaultLogger().Warn(fmt.Sprintf("store cache file(%s) error: %s", c.getDumpFileName(), err))
+				c.conf.Logger.Warn("[rcc-go-client]store cache file error", "file", c.getDumpFileName(), "err", err)
 			}
 		}
 	}
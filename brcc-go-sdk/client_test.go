@@ -0,0 +1,62 @@
+package rcc
+
+import (
+	"context"
+	"testing"
+)
+
+func newTestClientWithLRU(t *testing.T, maxEntries int) *Client {
+	t.Helper()
+	conf := &Conf{
+		ProjectName:   "test-project",
+		EnvName:       "test-env",
+		CacheBackend:  CacheBackendLRU,
+		CacheMaxBytes: int64(maxEntries) * assumedAvgEntryBytes,
+	}
+	if err := conf.normalize(context.Background()); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	return &Client{conf: conf, cache: newCache(conf)}
+}
+
+func TestProcessChunk_UnchangedValueSkipsChange(t *testing.T) {
+	c := newTestClientWithLRU(t, 10)
+
+	ce := c.processChunk(map[string]string{"a": "1"})
+	if _, ok := ce.Changes["a"]; !ok {
+		t.Fatalf("expected add change for a on first sight")
+	}
+
+	ce = c.processChunk(map[string]string{"a": "1"})
+	if _, ok := ce.Changes["a"]; ok {
+		t.Fatalf("expected no change for an unchanged value, got one")
+	}
+}
+
+// TestProcessChunk_EvictedUnchangedKeyIsRestored guards against a key that
+// an LRU eviction has dropped from the backend, but whose stale hash is
+// still sitting in Cache.hashes, being skipped forever by the hash
+// short-circuit even though it's no longer actually cached.
+func TestProcessChunk_EvictedUnchangedKeyIsRestored(t *testing.T) {
+	c := newTestClientWithLRU(t, 1)
+
+	if ce := c.processChunk(map[string]string{"a": "1"}); ce.Changes["a"] == nil {
+		t.Fatalf("expected add change for a")
+	}
+	// "b" evicts "a" from the size-1 LRU store, but a's hash remains in
+	// Cache.hashes.
+	if ce := c.processChunk(map[string]string{"b": "2"}); ce.Changes["b"] == nil {
+		t.Fatalf("expected add change for b")
+	}
+	if _, ok := c.cache.get("a"); ok {
+		t.Fatalf("expected a to have been evicted from the LRU store")
+	}
+
+	ce := c.processChunk(map[string]string{"a": "1"})
+	if _, ok := ce.Changes["a"]; !ok {
+		t.Fatalf("expected a to be restored after eviction, got no change")
+	}
+	if _, ok := c.cache.get("a"); !ok {
+		t.Fatalf("expected a back in the cache after reprocessing")
+	}
+}
@@ -0,0 +1,101 @@
+package rcc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CacheBackend selects the CacheStore implementation backing a Client's
+// Cache.
+type CacheBackend string
+
+const (
+	// CacheBackendMemory is the default unbounded sync.Map store.
+	CacheBackendMemory CacheBackend = "memory"
+	// CacheBackendLRU bounds the number of cached keys, evicting the
+	// least-recently-used ones once the entry limit derived from
+	// CacheMaxBytes is reached.
+	CacheBackendLRU CacheBackend = "lru"
+	// CacheBackendEncrypted persists the cache dump file AES-GCM encrypted
+	// with a key derived from CacheSecret.
+	CacheBackendEncrypted CacheBackend = "encrypted"
+)
+
+// Conf is the configuration used to create a Client.
+type Conf struct {
+	ProjectName string
+	EnvName     string
+
+	RequestTimeout time.Duration
+
+	// EnableCallback starts the background poller so Watch/WatchUpdate
+	// subscribers receive updates as they happen.
+	EnableCallback bool
+
+	// EnableCache persists the cache to CacheDir so preload can fall back
+	// to it when the remote RCC service is unreachable at startup.
+	EnableCache bool
+	CacheDir    string
+
+	// CacheBackend selects the CacheStore implementation. Defaults to
+	// CacheBackendMemory.
+	CacheBackend CacheBackend
+	// CacheMaxBytes sizes CacheBackendLRU: it is divided by an assumed
+	// 512 bytes per entry to give the entry limit, so it is not an exact
+	// memory bound. When unset the limit is 4096 entries. Ignored by other
+	// backends.
+	CacheMaxBytes int64
+	// CacheSecret derives the AES-GCM key for CacheBackendEncrypted. It is
+	// required when CacheBackend is CacheBackendEncrypted.
+	CacheSecret string
+
+	// Logger receives the SDK's internal log output. Defaults to a
+	// zap-backed implementation when nil.
+	Logger Logger
+
+	// AdminListen starts an opt-in HTTP admin server (GET /rcc/keys,
+	// /rcc/values, /rcc/value, /rcc/status and POST /rcc/refresh) on this
+	// address when non-empty, e.g. "127.0.0.1:9999".
+	AdminListen string
+	// AdminRedactKeys is a list of regexes matched against keys; matching
+	// values are masked in the /rcc/values admin response.
+	AdminRedactKeys []string
+
+	// PollerMaxBackoff caps the exponential backoff applied between poller
+	// restarts after a panic. Defaults to defaultPollerMaxBackoff.
+	PollerMaxBackoff time.Duration
+
+	// FetchConcurrency bounds how many chunk fetches sync runs at once for
+	// large namespaces. Defaults to defaultFetchConcurrency.
+	FetchConcurrency int
+
+	// CacheCompression gzip-compresses the cache dump file.
+	CacheCompression bool
+	// CacheKeepVersions is how many previous dump files are kept so
+	// preload can fall back to the last good one if the latest is
+	// unreadable.
+	CacheKeepVersions int
+}
+
+func (conf *Conf) normalize(ctx context.Context) error {
+	if conf.ProjectName == "" {
+		return fmt.Errorf("[rcc-go-client]conf.ProjectName is required")
+	}
+	if conf.EnvName == "" {
+		return fmt.Errorf("[rcc-go-client]conf.EnvName is required")
+	}
+	if conf.RequestTimeout <= 0 {
+		conf.RequestTimeout = 5 * time.Second
+	}
+	if conf.CacheBackend == "" {
+		conf.CacheBackend = CacheBackendMemory
+	}
+	if conf.CacheBackend == CacheBackendEncrypted && conf.CacheSecret == "" {
+		return fmt.Errorf("[rcc-go-client]conf.CacheSecret is required when CacheBackend is %s", CacheBackendEncrypted)
+	}
+	if conf.Logger == nil {
+		conf.Logger = newZapLogger()
+	}
+	return nil
+}
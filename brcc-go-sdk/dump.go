@@ -0,0 +1,158 @@
+package rcc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+)
+
+// cacheFileVersion is the dump file format version written by every
+// CacheStore implementation.
+const cacheFileVersion = 1
+
+// hashValue hashes value for both Cache's in-memory diffing and the dump
+// file's per-key hash column.
+func hashValue(value string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return h.Sum64()
+}
+
+type dumpEntry struct {
+	Value string `json:"value"`
+	Hash  uint64 `json:"hash"`
+}
+
+// dumpFile is the on-disk (or, for encrypted stores, pre-encryption)
+// representation of a cache dump: a version header plus a per-key hash so
+// loadFile can detect and reject a corrupt or partial write.
+type dumpFile struct {
+	Version int                  `json:"version"`
+	Entries map[string]dumpEntry `json:"entries"`
+}
+
+func newDumpFile(kv map[string]string) dumpFile {
+	entries := make(map[string]dumpEntry, len(kv))
+	for k, v := range kv {
+		entries[k] = dumpEntry{Value: v, Hash: hashValue(v)}
+	}
+	return dumpFile{Version: cacheFileVersion, Entries: entries}
+}
+
+// toKV validates the dump's version and each entry's hash, returning the
+// plain key/value map on success.
+func (d dumpFile) toKV() (map[string]string, error) {
+	if d.Version != cacheFileVersion {
+		return nil, fmt.Errorf("[rcc-go-client]unsupported cache file version %d", d.Version)
+	}
+	kv := make(map[string]string, len(d.Entries))
+	for k, e := range d.Entries {
+		if hashValue(e.Value) != e.Hash {
+			return nil, fmt.Errorf("[rcc-go-client]cache file entry %q failed hash check, file is corrupt", k)
+		}
+		kv[k] = e.Value
+	}
+	return kv, nil
+}
+
+// writeDumpPayload atomically writes payload (optionally gzip-compressed)
+// to fileName with a sha256 checksum footer, first rotating up to
+// keepVersions previous dumps out of the way so a bad write never clobbers
+// the last known-good one.
+func writeDumpPayload(fileName string, payload []byte, compress bool, keepVersions int) error {
+	if compress {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err := gw.Write(payload); err != nil {
+			return err
+		}
+		if err := gw.Close(); err != nil {
+			return err
+		}
+		payload = buf.Bytes()
+	}
+
+	sum := sha256.Sum256(payload)
+	out := append(payload, sum[:]...)
+
+	rotateDumpVersions(fileName, keepVersions)
+
+	tmp := fileName + ".tmp"
+	if err := ioutil.WriteFile(tmp, out, os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fileName)
+}
+
+// readDumpPayload reads, checksum-verifies and (if compress) decompresses
+// fileName, falling back through its up-to-keepVersions backups if the
+// latest dump is missing or corrupt.
+func readDumpPayload(fileName string, compress bool, keepVersions int) ([]byte, error) {
+	var lastErr error
+	for n := 0; n <= keepVersions; n++ {
+		candidate := fileName
+		if n > 0 {
+			candidate = dumpBackupName(fileName, n)
+		}
+
+		payload, err := readAndVerifyDump(candidate, compress)
+		if err == nil {
+			return payload, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func readAndVerifyDump(fileName string, compress bool) ([]byte, error) {
+	raw, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < sha256.Size {
+		return nil, fmt.Errorf("[rcc-go-client]cache file %s is too short to contain a checksum footer", fileName)
+	}
+
+	payload, footer := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	sum := sha256.Sum256(payload)
+	if !bytes.Equal(sum[:], footer) {
+		return nil, fmt.Errorf("[rcc-go-client]cache file %s failed checksum verification", fileName)
+	}
+
+	if !compress {
+		return payload, nil
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	return ioutil.ReadAll(gr)
+}
+
+func dumpBackupName(fileName string, n int) string {
+	return fmt.Sprintf("%s.bak%d", fileName, n)
+}
+
+// rotateDumpVersions shifts fileName -> fileName.bak1 -> fileName.bak2 ...
+// dropping anything beyond keepVersions, before a new fileName is written.
+func rotateDumpVersions(fileName string, keepVersions int) {
+	if keepVersions <= 0 {
+		return
+	}
+	for n := keepVersions; n >= 1; n-- {
+		src := fileName
+		if n > 1 {
+			src = dumpBackupName(fileName, n-1)
+		}
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		_ = os.Rename(src, dumpBackupName(fileName, n))
+	}
+}
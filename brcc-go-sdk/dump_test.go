@@ -0,0 +1,86 @@
+package rcc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteReadDumpPayload_RoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rcc-dump-")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "dump")
+	want := []byte(`{"hello":"world"}`)
+
+	if err := writeDumpPayload(fileName, want, true, 2); err != nil {
+		t.Fatalf("writeDumpPayload: %v", err)
+	}
+
+	got, err := readDumpPayload(fileName, true, 2)
+	if err != nil {
+		t.Fatalf("readDumpPayload: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("readDumpPayload = %q, want %q", got, want)
+	}
+}
+
+func TestReadDumpPayload_FallsBackToPreviousVersionOnCorruption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rcc-dump-")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "dump")
+
+	if err := writeDumpPayload(fileName, []byte("good-version"), false, 2); err != nil {
+		t.Fatalf("writeDumpPayload(good): %v", err)
+	}
+	if err := writeDumpPayload(fileName, []byte("corrupted-version"), false, 2); err != nil {
+		t.Fatalf("writeDumpPayload(bad): %v", err)
+	}
+
+	// fileName now holds "corrupted-version" and fileName.bak1 holds
+	// "good-version". Truncate the latest write so it fails checksum
+	// verification, as a crash mid-write would.
+	if err := ioutil.WriteFile(fileName, []byte("truncated"), os.ModePerm); err != nil {
+		t.Fatalf("truncate latest dump: %v", err)
+	}
+
+	got, err := readDumpPayload(fileName, false, 2)
+	if err != nil {
+		t.Fatalf("readDumpPayload: expected fallback to succeed, got err: %v", err)
+	}
+	if string(got) != "good-version" {
+		t.Fatalf("readDumpPayload = %q, want fallback to %q", got, "good-version")
+	}
+}
+
+func TestRotateDumpVersions_DropsBeyondKeepVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rcc-dump-")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "dump")
+
+	for i := 0; i < 3; i++ {
+		if err := writeDumpPayload(fileName, []byte{byte('0' + i)}, false, 1); err != nil {
+			t.Fatalf("writeDumpPayload #%d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(dumpBackupName(fileName, 2)); err == nil {
+		t.Fatalf("expected %s to have been dropped, keepVersions is 1", dumpBackupName(fileName, 2))
+	}
+	if _, err := os.Stat(dumpBackupName(fileName, 1)); err != nil {
+		t.Fatalf("expected %s to exist: %v", dumpBackupName(fileName, 1), err)
+	}
+}
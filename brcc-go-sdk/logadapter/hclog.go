@@ -0,0 +1,24 @@
+// Package logadapter adapts third-party loggers to rcc.Logger so services
+// embedding the SDK can route its log output through whatever logger they
+// already use.
+package logadapter
+
+import (
+	rcc "github.com/baidu/brcc/brcc-go-sdk"
+	"github.com/hashicorp/go-hclog"
+)
+
+// hclogLogger adapts an hclog.Logger to rcc.Logger.
+type hclogLogger struct {
+	l hclog.Logger
+}
+
+// NewHclogLogger wraps l as an rcc.Logger for use as Conf.Logger.
+func NewHclogLogger(l hclog.Logger) rcc.Logger {
+	return &hclogLogger{l: l}
+}
+
+func (h *hclogLogger) Debug(msg string, kv ...interface{}) { h.l.Debug(msg, kv...) }
+func (h *hclogLogger) Info(msg string, kv ...interface{})  { h.l.Info(msg, kv...) }
+func (h *hclogLogger) Warn(msg string, kv ...interface{})  { h.l.Warn(msg, kv...) }
+func (h *hclogLogger) Error(msg string, kv ...interface{}) { h.l.Error(msg, kv...) }
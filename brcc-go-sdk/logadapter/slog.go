@@ -0,0 +1,22 @@
+package logadapter
+
+import (
+	"log/slog"
+
+	rcc "github.com/baidu/brcc/brcc-go-sdk"
+)
+
+// slogLogger adapts a *slog.Logger to rcc.Logger.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger wraps l as an rcc.Logger for use as Conf.Logger.
+func NewSlogLogger(l *slog.Logger) rcc.Logger {
+	return &slogLogger{l: l}
+}
+
+func (s *slogLogger) Debug(msg string, kv ...interface{}) { s.l.Debug(msg, kv...) }
+func (s *slogLogger) Info(msg string, kv ...interface{})  { s.l.Info(msg, kv...) }
+func (s *slogLogger) Warn(msg string, kv ...interface{})  { s.l.Warn(msg, kv...) }
+func (s *slogLogger) Error(msg string, kv ...interface{}) { s.l.Error(msg, kv...) }
@@ -0,0 +1,13 @@
+package rcc
+
+// Logger is the structured logging interface used internally by the SDK.
+// Conf.Logger lets a host application route SDK logs into whatever logger
+// it already uses (zap, hclog, slog, ...) instead of having the SDK's own
+// zap instance forced on it. kv is alternating key/value pairs, the same
+// convention as hclog and slog.
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
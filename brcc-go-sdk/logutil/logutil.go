@@ -0,0 +1,30 @@
+// Package logutil provides the SDK's default zap logger.
+package logutil
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	once   sync.Once
+	logger *zap.Logger
+)
+
+// InitLog initializes the package-level zap logger. It is safe to call
+// more than once; only the first call takes effect.
+func InitLog() {
+	once.Do(func() {
+		logger, _ = zap.NewProduction()
+	})
+}
+
+// DefaultLogger returns the package-level zap logger, initializing it with
+// default settings if InitLog hasn't been called yet.
+func DefaultLogger() *zap.Logger {
+	if logger == nil {
+		InitLog()
+	}
+	return logger
+}
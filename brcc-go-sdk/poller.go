@@ -0,0 +1,55 @@
+package rcc
+
+import (
+	"context"
+	"time"
+)
+
+// pollInterval is how often pollerRcc checks for updates.
+const pollInterval = 30 * time.Second
+
+// IPoller drives background synchronization for a Client.
+type IPoller interface {
+	start()
+	stop()
+	preload() error
+}
+
+type pollerRcc struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	conf         *Conf
+	handleUpdate func(int) error
+	versionId    int
+}
+
+func newPollerRcc(ctx context.Context, conf *Conf, handleUpdate func(int) error) IPoller {
+	ctx, cancel := context.WithCancel(ctx)
+	return &pollerRcc{ctx: ctx, cancel: cancel, conf: conf, handleUpdate: handleUpdate}
+}
+
+// preload fetches the full namespace once, synchronously, before Start
+// returns.
+func (p *pollerRcc) preload() error {
+	return p.handleUpdate(0)
+}
+
+func (p *pollerRcc) start() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.handleUpdate(p.versionId); err != nil {
+				p.conf.Logger.Warn("[rcc-go-client]poll update error", "err", err)
+			}
+		}
+	}
+}
+
+func (p *pollerRcc) stop() {
+	p.cancel()
+}
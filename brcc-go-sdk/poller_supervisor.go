@@ -0,0 +1,64 @@
+package rcc
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// defaultPollerMaxBackoff caps poller restart backoff when
+// Conf.PollerMaxBackoff is unset.
+const defaultPollerMaxBackoff = time.Minute
+
+// runPollerSupervised runs the poller, recovering panics (in the same style
+// as Watch's callback recovery) and restarting it with exponential backoff
+// capped at Conf.PollerMaxBackoff, instead of letting one panic silently
+// kill background sync forever while GetValue keeps serving stale results.
+func (c *Client) runPollerSupervised() {
+	maxBackoff := c.conf.PollerMaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultPollerMaxBackoff
+	}
+
+	backoff := time.Second
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	for {
+		if stopped := c.runPollerOnce(); stopped {
+			return
+		}
+
+		c.adminStatus.recordPollerRestart()
+		c.conf.Logger.Warn("[rcc-go-client]poller restarting after panic",
+			"projectName", c.conf.ProjectName, "envName", c.conf.EnvName, "backoff", backoff)
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// runPollerOnce runs the poller until it returns. stopped is true when the
+// poller returned normally (context cancelled, i.e. Stop was called) and
+// false when it panicked, in which case the caller should restart it.
+func (c *Client) runPollerOnce() (stopped bool) {
+	stopped = true
+	defer func() {
+		if r := recover(); r != nil {
+			c.adminStatus.recordPanic(fmt.Sprintf("%v\n%s", r, debug.Stack()))
+			c.conf.Logger.Error("[rcc-go-client]poller panic",
+				"projectName", c.conf.ProjectName, "envName", c.conf.EnvName, "panic", r)
+			stopped = false
+		}
+	}()
+	c.poller.start()
+	return
+}
@@ -0,0 +1,60 @@
+package rcc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// IRequester fetches and JSON-decodes a remote RCC API response into result.
+type IRequester interface {
+	Get(url string, result interface{}) error
+}
+
+type httpRequester struct {
+	ctx    context.Context
+	client *http.Client
+}
+
+func newHTTPRequester(ctx context.Context, client *http.Client) IRequester {
+	return &httpRequester{ctx: ctx, client: client}
+}
+
+func (r *httpRequester) Get(url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("[rcc-go-client]unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// itemsPage is one page of a chunked items fetch.
+type itemsPage struct {
+	Items []Item `json:"items"`
+	Total int    `json:"total"`
+}
+
+// apiItemsPage builds the URL used to fetch one (offset, limit) page of a
+// namespace's items at versionId.
+func apiItemsPage(conf *Conf, versionId, offset, limit int) string {
+	return fmt.Sprintf("/api/items?project=%s&env=%s&version=%d&offset=%d&limit=%d",
+		conf.ProjectName, conf.EnvName, versionId, offset, limit)
+}
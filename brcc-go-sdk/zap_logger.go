@@ -0,0 +1,42 @@
+package rcc
+
+import (
+	"github.com/baidu/brcc/brcc-go-sdk/logutil"
+	"go.uber.org/zap"
+)
+
+// zapLogger adapts logutil's zap logger to the Logger interface. It is the
+// default used when Conf.Logger is nil, preserving the SDK's previous
+// logging behavior for callers that don't set one.
+type zapLogger struct{}
+
+func newZapLogger() Logger { return zapLogger{} }
+
+func (zapLogger) Debug(msg string, kv ...interface{}) {
+	logutil.DefaultLogger().Debug(msg, toZapFields(kv)...)
+}
+
+func (zapLogger) Info(msg string, kv ...interface{}) {
+	logutil.DefaultLogger().Info(msg, toZapFields(kv)...)
+}
+
+func (zapLogger) Warn(msg string, kv ...interface{}) {
+	logutil.DefaultLogger().Warn(msg, toZapFields(kv)...)
+}
+
+func (zapLogger) Error(msg string, kv ...interface{}) {
+	logutil.DefaultLogger().Error(msg, toZapFields(kv)...)
+}
+
+// toZapFields converts alternating key/value pairs into zap.Field values.
+func toZapFields(kv []interface{}) []zap.Field {
+	fields := make([]zap.Field, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		fields = append(fields, zap.Any(key, kv[i+1]))
+	}
+	return fields
+}